@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	nginxPlus = flag.Bool("nginx-plus", false,
+		"Enable support for NGINX Plus")
+
+	appProtectEnabled = flag.Bool("enable-app-protect", false,
+		"Enable support for NGINX App Protect. Requires -nginx-plus.")
+
+	internalRoutesEnabled = flag.Bool("enable-internal-routes", false,
+		"Enable support for internal routes with NGINX Service Mesh.")
+)
+
+func main() {
+	flag.Parse()
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatalf("error building in-cluster config: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("error creating Kubernetes client: %v", err)
+	}
+
+	controller := k8s.NewLoadBalancerController(k8s.NewLoadBalancerControllerInput{
+		KubeClient:            kubeClient,
+		IsNginxPlus:           *nginxPlus,
+		AppProtectEnabled:     *appProtectEnabled,
+		InternalRoutesEnabled: *internalRoutesEnabled,
+	})
+
+	log.Println("Starting the Ingress controller")
+	controller.Run(wait.NeverStop)
+}
@@ -0,0 +1,83 @@
+// Command webhook runs a ValidatingAdmissionWebhook server that rejects invalid Ingress
+// resources at kubectl apply time, using the same validation the controller applies during
+// its sync loop.
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/webhook"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	webhookPort = flag.Int("webhook-port", 8443,
+		"Port to listen on for AdmissionReview requests from the API server.")
+
+	tlsCertPath = flag.String("tls-cert", "/etc/nginx-ingress/webhook-tls/tls.crt",
+		"Path to the TLS certificate used to serve the webhook.")
+
+	tlsKeyPath = flag.String("tls-key", "/etc/nginx-ingress/webhook-tls/tls.key",
+		"Path to the TLS private key used to serve the webhook.")
+
+	nginxPlus = flag.Bool("nginx-plus", false,
+		"Enable support for NGINX Plus. Must match the controller's -nginx-plus setting.")
+
+	appProtectEnabled = flag.Bool("enable-app-protect", false,
+		"Enable support for NGINX App Protect. Must match the controller's -enable-app-protect setting.")
+
+	internalRoutesEnabled = flag.Bool("enable-internal-routes", false,
+		"Enable support for internal routes with NGINX Service Mesh. Must match the controller's -enable-internal-routes setting.")
+)
+
+func main() {
+	flag.Parse()
+
+	cert, err := tls.LoadX509KeyPair(*tlsCertPath, *tlsKeyPath)
+	if err != nil {
+		log.Fatalf("could not load webhook TLS certificate: %v", err)
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatalf("error building in-cluster config: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("error creating Kubernetes client: %v", err)
+	}
+
+	// The controller's own Ingress informer is reused here purely to keep an
+	// IngressConflictValidator in sync with the cluster, so the webhook rejects a
+	// conflicting Ingress at kubectl apply time instead of only catching it later in the
+	// controller's own sync loop.
+	controller := k8s.NewLoadBalancerController(k8s.NewLoadBalancerControllerInput{
+		KubeClient:            kubeClient,
+		IsNginxPlus:           *nginxPlus,
+		AppProtectEnabled:     *appProtectEnabled,
+		InternalRoutesEnabled: *internalRoutesEnabled,
+	})
+	go controller.Run(wait.NeverStop)
+
+	server := &http.Server{
+		Addr: fmt.Sprintf(":%d", *webhookPort),
+		Handler: webhook.Handler(webhook.Config{
+			IsPlus:                *nginxPlus,
+			AppProtectEnabled:     *appProtectEnabled,
+			InternalRoutesEnabled: *internalRoutesEnabled,
+			Conflicts:             controller.Conflicts(),
+		}),
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+
+	log.Printf("Starting the validating webhook server on port %d", *webhookPort)
+	log.Fatal(server.ListenAndServeTLS("", ""))
+}
@@ -0,0 +1,60 @@
+package configs
+
+import (
+	networking "k8s.io/api/networking/v1beta1"
+)
+
+// pathRegexAnnotation is the annotation validated by internal/k8s's validateIngressAnnotations
+// and translated into a location modifier here.
+const pathRegexAnnotation = "nginx.org/path-regex"
+
+// pathTypeModifiers maps the nginx.org/path-regex annotation values to the NGINX location
+// modifier used when generating the location block for a path.
+var pathTypeModifiers = map[string]string{
+	"prefix":               "^~",
+	"exact":                "=",
+	"regex":                "~*",
+	"case_sensitive_regex": "~",
+}
+
+// LocationModifier returns the NGINX location modifier for the given nginx.org/path-regex
+// annotation value. It returns an empty string, the default prefix-match location, when
+// pathType is unset or unrecognized.
+func LocationModifier(pathType string) string {
+	return pathTypeModifiers[pathType]
+}
+
+// Location is a single NGINX location block generated from one Ingress path rule.
+type Location struct {
+	// Path is the path as it should appear in the generated location directive, including
+	// the modifier selected by the nginx.org/path-regex annotation, e.g. "~* /app" for a
+	// path-regex value of "regex".
+	Path string
+}
+
+// GenerateIngressLocations builds the Location for every HTTP path rule across all of ing's
+// rules, applying the NGINX location modifier selected by ing's nginx.org/path-regex
+// annotation via LocationModifier.
+func GenerateIngressLocations(ing *networking.Ingress) []Location {
+	modifier := LocationModifier(ing.Annotations[pathRegexAnnotation])
+
+	var locations []Location
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			locations = append(locations, Location{Path: locationPath(modifier, p.Path)})
+		}
+	}
+	return locations
+}
+
+// locationPath prefixes path with modifier, NGINX's location-block syntax for selecting a
+// non-default match type, e.g. locationPath("~*", "/app") returns "~* /app".
+func locationPath(modifier, path string) string {
+	if modifier == "" {
+		return path
+	}
+	return modifier + " " + path
+}
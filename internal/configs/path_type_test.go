@@ -0,0 +1,88 @@
+package configs
+
+import (
+	"reflect"
+	"testing"
+
+	networking "k8s.io/api/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLocationModifier(t *testing.T) {
+	tests := []struct {
+		pathType string
+		expected string
+	}{
+		{"prefix", "^~"},
+		{"exact", "="},
+		{"regex", "~*"},
+		{"case_sensitive_regex", "~"},
+		{"", ""},
+		{"unknown", ""},
+	}
+
+	for _, test := range tests {
+		result := LocationModifier(test.pathType)
+		if result != test.expected {
+			t.Errorf("LocationModifier(%q) returned %q but expected %q", test.pathType, result, test.expected)
+		}
+	}
+}
+
+func ingressWithPaths(annotations map[string]string, paths ...string) *networking.Ingress {
+	httpPaths := make([]networking.HTTPIngressPath, 0, len(paths))
+	for _, p := range paths {
+		httpPaths = append(httpPaths, networking.HTTPIngressPath{Path: p})
+	}
+
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Annotations: annotations},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{Paths: httpPaths},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateIngressLocations(t *testing.T) {
+	tests := []struct {
+		msg         string
+		annotations map[string]string
+		paths       []string
+		expected    []Location
+	}{
+		{
+			msg:         "no path-regex annotation uses the default prefix match",
+			annotations: nil,
+			paths:       []string{"/app"},
+			expected:    []Location{{Path: "/app"}},
+		},
+		{
+			msg:         "path-regex: regex applies the case-insensitive regex modifier",
+			annotations: map[string]string{"nginx.org/path-regex": "regex"},
+			paths:       []string{"/app", "/app/v1"},
+			expected:    []Location{{Path: "~* /app"}, {Path: "~* /app/v1"}},
+		},
+		{
+			msg:         "path-regex: exact applies the exact-match modifier",
+			annotations: map[string]string{"nginx.org/path-regex": "exact"},
+			paths:       []string{"/app"},
+			expected:    []Location{{Path: "= /app"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			ing := ingressWithPaths(test.annotations, test.paths...)
+			result := GenerateIngressLocations(ing)
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("GenerateIngressLocations() returned %v but expected %v", result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// claim records that an Ingress owns a (path, pathType) tuple under some host.
+type claim struct {
+	path     string
+	pathType networking.PathType
+	owner    string // "namespace/name" of the owning Ingress
+}
+
+// IngressConflictValidator tracks which Ingress owns which host/path across all Ingresses the
+// controller watches, so that two Ingresses claiming an overlapping host and path can be
+// rejected instead of silently overwriting each other's NGINX configuration.
+//
+// Mergeable masters carry no paths of their own and are never recorded, so a minion's paths
+// never conflict with the master that owns them. Mergeable minions and regular (non-mergeable)
+// Ingresses are recorded and checked against one another.
+type IngressConflictValidator struct {
+	mu     sync.RWMutex
+	claims map[string][]claim // host -> claims
+}
+
+// NewIngressConflictValidator creates an empty IngressConflictValidator.
+func NewIngressConflictValidator() *IngressConflictValidator {
+	return &IngressConflictValidator{
+		claims: make(map[string][]claim),
+	}
+}
+
+// Validate checks whether the rules of ing overlap with rules already claimed by a different
+// Ingress, returning a field.ErrorList of Duplicate errors for every collision. It does not
+// modify the store; callers should call Upsert once the Ingress is admitted.
+//
+// Validate and Upsert are separate critical sections, so a caller that validates two
+// conflicting Ingresses concurrently and upserts only on success could let both pass
+// Validate before either calls Upsert. Callers that admit Ingresses concurrently must use
+// ValidateAndUpsert instead, which closes that window.
+func (v *IngressConflictValidator) Validate(ing *networking.Ingress, fieldPath *field.Path) field.ErrorList {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return v.validateLocked(ing, fieldPath)
+}
+
+// ValidateAndUpsert atomically validates ing against the claims already recorded and, if it
+// is conflict-free, upserts its own claims, all under a single write lock. This is the
+// check-then-act operation callers that may admit Ingresses concurrently must use instead of
+// a separate Validate then Upsert, which would let two conflicting Ingresses both pass
+// Validate before either one's claims are recorded.
+func (v *IngressConflictValidator) ValidateAndUpsert(ing *networking.Ingress, fieldPath *field.Path) field.ErrorList {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	allErrs := v.validateLocked(ing, fieldPath)
+	if len(allErrs) == 0 {
+		v.upsertLocked(ing)
+	}
+	return allErrs
+}
+
+func (v *IngressConflictValidator) validateLocked(ing *networking.Ingress, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ing.Annotations[mergeableIngressTypeAnnotation] == "master" {
+		return allErrs
+	}
+
+	owner := ingressOwnerKey(ing)
+
+	for i, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, p := range rule.HTTP.Paths {
+			pathType := resolvePathType(p.PathType)
+
+			for _, existing := range v.claims[rule.Host] {
+				if existing.owner == owner {
+					continue
+				}
+				if !pathsOverlap(p.Path, pathType, existing.path, existing.pathType) {
+					continue
+				}
+
+				pathPath := fieldPath.Child("rules").Index(i).Child("http").Child("paths").Index(j).Child("path")
+				err := field.Duplicate(pathPath, p.Path)
+				err.Detail = fmt.Sprintf("already defined by %q", existing.owner)
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// Upsert records the (host, path, pathType) tuples claimed by ing as owned by it, replacing
+// any tuples it previously owned. A mergeable master owns no paths, so for one it only drops
+// whatever tuples were previously recorded under its name.
+func (v *IngressConflictValidator) Upsert(ing *networking.Ingress) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.upsertLocked(ing)
+}
+
+func (v *IngressConflictValidator) upsertLocked(ing *networking.Ingress) {
+	owner := ingressOwnerKey(ing)
+
+	v.removeLocked(owner)
+
+	if ing.Annotations[mergeableIngressTypeAnnotation] == "master" {
+		return
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			v.claims[rule.Host] = append(v.claims[rule.Host], claim{
+				path:     p.Path,
+				pathType: resolvePathType(p.PathType),
+				owner:    owner,
+			})
+		}
+	}
+}
+
+// Remove deletes every tuple owned by the Ingress identified by namespace/name, for example
+// when the Ingress is deleted.
+func (v *IngressConflictValidator) Remove(namespace, name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.removeLocked(namespace + "/" + name)
+}
+
+func (v *IngressConflictValidator) removeLocked(owner string) {
+	for host, claims := range v.claims {
+		kept := claims[:0]
+		for _, c := range claims {
+			if c.owner != owner {
+				kept = append(kept, c)
+			}
+		}
+		if len(kept) == 0 {
+			delete(v.claims, host)
+		} else {
+			v.claims[host] = kept
+		}
+	}
+}
+
+func ingressOwnerKey(ing *networking.Ingress) string {
+	return ing.Namespace + "/" + ing.Name
+}
+
+func resolvePathType(pathType *networking.PathType) networking.PathType {
+	if pathType == nil {
+		return networking.PathTypeImplementationSpecific
+	}
+	return *pathType
+}
+
+// pathsOverlap reports whether two (path, pathType) claims under the same host would collide
+// in the generated NGINX configuration. Exact claims only conflict on an identical path.
+// Prefix claims also conflict with any path nested under them.
+func pathsOverlap(aPath string, aType networking.PathType, bPath string, bType networking.PathType) bool {
+	if aPath == bPath {
+		return true
+	}
+	if aType == networking.PathTypePrefix && isPathPrefixOf(aPath, bPath) {
+		return true
+	}
+	if bType == networking.PathTypePrefix && isPathPrefixOf(bPath, aPath) {
+		return true
+	}
+	return false
+}
+
+// isPathPrefixOf reports whether prefix matches path as a directory prefix, e.g. "/api"
+// matches "/api/v1" but not "/apiextra".
+func isPathPrefixOf(prefix, path string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) {
+		return true
+	}
+	return strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
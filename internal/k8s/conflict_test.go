@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func prefixPathType() *networking.PathType {
+	t := networking.PathTypePrefix
+	return &t
+}
+
+func exactPathType() *networking.PathType {
+	t := networking.PathTypeExact
+	return &t
+}
+
+func ingressWithPath(namespace, name, host, path string, pathType *networking.PathType, annotations map[string]string) *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: annotations,
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: pathType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestIngressConflictValidator(t *testing.T) {
+	tests := []struct {
+		msg            string
+		existing       []*networking.Ingress
+		candidate      *networking.Ingress
+		expectedErrors []string
+	}{
+		{
+			msg: "same-namespace collision",
+			existing: []*networking.Ingress{
+				ingressWithPath("default", "foo", "example.com", "/", prefixPathType(), nil),
+			},
+			candidate: ingressWithPath("default", "bar", "example.com", "/", prefixPathType(), nil),
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Duplicate value: "/": already defined by "default/foo"`,
+			},
+		},
+		{
+			msg: "cross-namespace collision",
+			existing: []*networking.Ingress{
+				ingressWithPath("team-a", "foo", "example.com", "/app", prefixPathType(), nil),
+			},
+			candidate: ingressWithPath("team-b", "foo", "example.com", "/app", prefixPathType(), nil),
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Duplicate value: "/app": already defined by "team-a/foo"`,
+			},
+		},
+		{
+			msg: "master and minion under the same host is not a conflict",
+			existing: []*networking.Ingress{
+				ingressWithPath("default", "master", "example.com", "", nil, map[string]string{"nginx.org/mergeable-ingress-type": "master"}),
+			},
+			candidate:      ingressWithPath("default", "minion", "example.com", "/app", prefixPathType(), map[string]string{"nginx.org/mergeable-ingress-type": "minion"}),
+			expectedErrors: nil,
+		},
+		{
+			msg: "two minions claiming the same host and path is a conflict",
+			existing: []*networking.Ingress{
+				ingressWithPath("default", "minion-a", "example.com", "/app", prefixPathType(), map[string]string{"nginx.org/mergeable-ingress-type": "minion"}),
+			},
+			candidate: ingressWithPath("default", "minion-b", "example.com", "/app", prefixPathType(), map[string]string{"nginx.org/mergeable-ingress-type": "minion"}),
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Duplicate value: "/app": already defined by "default/minion-a"`,
+			},
+		},
+		{
+			msg: "an Exact path nested under an existing Prefix path conflicts",
+			existing: []*networking.Ingress{
+				ingressWithPath("default", "foo", "example.com", "/app", prefixPathType(), nil),
+			},
+			candidate: ingressWithPath("default", "bar", "example.com", "/app/v1", exactPathType(), nil),
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Duplicate value: "/app/v1": already defined by "default/foo"`,
+			},
+		},
+		{
+			msg: "an Exact path outside of an existing Prefix path does not conflict",
+			existing: []*networking.Ingress{
+				ingressWithPath("default", "foo", "example.com", "/app", prefixPathType(), nil),
+			},
+			candidate:      ingressWithPath("default", "bar", "example.com", "/application", exactPathType(), nil),
+			expectedErrors: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			v := NewIngressConflictValidator()
+			for _, ing := range test.existing {
+				v.Upsert(ing)
+			}
+
+			allErrs := v.Validate(test.candidate, field.NewPath("spec"))
+			assertion := assertErrors("IngressConflictValidator.Validate()", test.msg, allErrs, test.expectedErrors)
+			if assertion != "" {
+				t.Error(assertion)
+			}
+		})
+	}
+}
+
+func TestIngressConflictValidatorRemove(t *testing.T) {
+	v := NewIngressConflictValidator()
+	ing := ingressWithPath("default", "foo", "example.com", "/", prefixPathType(), nil)
+	v.Upsert(ing)
+
+	v.Remove("default", "foo")
+
+	candidate := ingressWithPath("default", "bar", "example.com", "/", prefixPathType(), nil)
+	allErrs := v.Validate(candidate, field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		t.Errorf("expected no conflicts after Remove, got %v", errorListToStrings(allErrs))
+	}
+}
+
+func TestIngressConflictValidatorValidateAndUpsert(t *testing.T) {
+	v := NewIngressConflictValidator()
+	foo := ingressWithPath("default", "foo", "example.com", "/", prefixPathType(), nil)
+
+	if allErrs := v.ValidateAndUpsert(foo, field.NewPath("spec")); len(allErrs) != 0 {
+		t.Fatalf("expected foo to be admitted, got %v", errorListToStrings(allErrs))
+	}
+
+	bar := ingressWithPath("default", "bar", "example.com", "/", prefixPathType(), nil)
+	allErrs := v.ValidateAndUpsert(bar, field.NewPath("spec"))
+	expected := []string{`spec.rules[0].http.paths[0].path: Duplicate value: "/": already defined by "default/foo"`}
+	if assertion := assertErrors("IngressConflictValidator.ValidateAndUpsert()", "rejected candidate is not upserted", allErrs, expected); assertion != "" {
+		t.Error(assertion)
+	}
+
+	// bar was rejected, so its claim must not have been recorded: a later Ingress with the
+	// same host/path should conflict with foo, not bar.
+	baz := ingressWithPath("default", "baz", "example.com", "/", prefixPathType(), nil)
+	allErrs = v.ValidateAndUpsert(baz, field.NewPath("spec"))
+	expected = []string{`spec.rules[0].http.paths[0].path: Duplicate value: "/": already defined by "default/foo"`}
+	if assertion := assertErrors("IngressConflictValidator.ValidateAndUpsert()", "rejected candidate never claims its paths", allErrs, expected); assertion != "" {
+		t.Error(assertion)
+	}
+}
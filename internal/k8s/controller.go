@@ -0,0 +1,135 @@
+package k8s
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// resyncPeriod is how often the Ingress informer re-delivers every cached Ingress to its update
+// handler, in addition to the add/update/delete events it is notified of.
+const resyncPeriod = 30 * time.Second
+
+// NewLoadBalancerControllerInput holds the options used to construct a LoadBalancerController.
+type NewLoadBalancerControllerInput struct {
+	KubeClient            kubernetes.Interface
+	IsNginxPlus           bool
+	AppProtectEnabled     bool
+	InternalRoutesEnabled bool
+}
+
+// LoadBalancerController watches Kubernetes API and reconfigures NGINX based on the state of
+// the cluster.
+type LoadBalancerController struct {
+	client                kubernetes.Interface
+	isNginxPlus           bool
+	appProtectEnabled     bool
+	internalRoutesEnabled bool
+	ingressConflicts      *IngressConflictValidator
+	ingressInformer       cache.SharedIndexInformer
+}
+
+// NewLoadBalancerController creates a controller and registers its Ingress add/update/delete
+// handlers with a new Ingress informer. Call Run to start watching.
+func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalancerController {
+	lbc := &LoadBalancerController{
+		client:                input.KubeClient,
+		isNginxPlus:           input.IsNginxPlus,
+		appProtectEnabled:     input.AppProtectEnabled,
+		internalRoutesEnabled: input.InternalRoutesEnabled,
+		ingressConflicts:      NewIngressConflictValidator(),
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(input.KubeClient, resyncPeriod)
+	lbc.ingressInformer = informerFactory.Networking().V1beta1().Ingresses().Informer()
+	lbc.ingressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    lbc.addIngress,
+		UpdateFunc: func(_, obj interface{}) { lbc.addIngress(obj) },
+		DeleteFunc: lbc.deleteIngress,
+	})
+
+	return lbc
+}
+
+// Conflicts returns the IngressConflictValidator the controller populates from its Ingress
+// informer, so other components that admit Ingresses against the same cluster (such as the
+// validating webhook) can consult and contribute to the same set of host/path claims.
+func (lbc *LoadBalancerController) Conflicts() *IngressConflictValidator {
+	return lbc.ingressConflicts
+}
+
+// Run starts the Ingress informer and blocks until stopCh is closed.
+func (lbc *LoadBalancerController) Run(stopCh <-chan struct{}) {
+	go lbc.ingressInformer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, lbc.ingressInformer.HasSynced) {
+		log.Println("timed out waiting for the Ingress informer cache to sync")
+		return
+	}
+
+	<-stopCh
+}
+
+// addIngress is the informer AddFunc/UpdateFunc handler: it validates the Ingress before the
+// controller admits it, logging and discarding anything that fails validateIngressResource
+// instead of letting it reach config generation.
+func (lbc *LoadBalancerController) addIngress(obj interface{}) {
+	ing, ok := obj.(*networking.Ingress)
+	if !ok {
+		log.Printf("expected an Ingress, got %T", obj)
+		return
+	}
+
+	if allErrs := lbc.validateIngressResource(ing); len(allErrs) > 0 {
+		log.Printf("rejecting invalid Ingress %s/%s: %s", ing.Namespace, ing.Name, strings.Join(errorListToStrings(allErrs), ", "))
+		return
+	}
+}
+
+// deleteIngress is the informer DeleteFunc handler: it forgets the host/path claims owned by
+// the deleted Ingress.
+func (lbc *LoadBalancerController) deleteIngress(obj interface{}) {
+	ing, ok := obj.(*networking.Ingress)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			ing, ok = tombstone.Obj.(*networking.Ingress)
+		}
+		if !ok {
+			log.Printf("expected an Ingress, got %T", obj)
+			return
+		}
+	}
+
+	lbc.removeIngressResource(ing.Namespace, ing.Name)
+}
+
+// validateIngressResource runs admission-style validation against an Ingress before it is
+// admitted by the controller's Ingress informer (see addIngress). Ingresses that fail
+// validation are rejected and left out of the generated configuration rather than causing a
+// config-gen failure further down the sync pipeline. In addition to the self-contained checks
+// in validateIngress, it consults ingressConflicts so that an Ingress whose host/path rules
+// collide with another, already-admitted Ingress is rejected instead of silently taking over
+// that traffic. The conflict check and the resulting claim are performed atomically, so two
+// Ingresses admitted concurrently cannot both pass the conflict check before either one's
+// claim is recorded.
+func (lbc *LoadBalancerController) validateIngressResource(ing *networking.Ingress) field.ErrorList {
+	allErrs := validateIngress(ing, lbc.isNginxPlus, lbc.appProtectEnabled, lbc.internalRoutesEnabled)
+	if len(allErrs) > 0 {
+		// Already invalid on its own terms; report conflicts too but never claim its paths.
+		return append(allErrs, lbc.ingressConflicts.Validate(ing, field.NewPath("spec"))...)
+	}
+
+	return lbc.ingressConflicts.ValidateAndUpsert(ing, field.NewPath("spec"))
+}
+
+// removeIngressResource forgets the host/path claims owned by a deleted Ingress so that a
+// future Ingress can reuse them without being rejected as a conflict.
+func (lbc *LoadBalancerController) removeIngressResource(namespace, name string) {
+	lbc.ingressConflicts.Remove(namespace, name)
+}
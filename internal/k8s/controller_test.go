@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestLoadBalancerControllerValidateIngressResource(t *testing.T) {
+	lbc := &LoadBalancerController{ingressConflicts: NewIngressConflictValidator()}
+
+	foo := ingressWithPath("default", "foo", "example.com", "/", prefixPathType(), nil)
+	if allErrs := lbc.validateIngressResource(foo); len(allErrs) != 0 {
+		t.Fatalf("expected foo to be admitted, got %v", errorListToStrings(allErrs))
+	}
+
+	bar := ingressWithPath("default", "bar", "example.com", "/", prefixPathType(), nil)
+	allErrs := lbc.validateIngressResource(bar)
+	expected := []string{`spec.rules[0].http.paths[0].path: Duplicate value: "/": already defined by "default/foo"`}
+	if assertion := assertErrors("LoadBalancerController.validateIngressResource()", "conflicting Ingress is rejected", allErrs, expected); assertion != "" {
+		t.Error(assertion)
+	}
+
+	lbc.removeIngressResource("default", "foo")
+
+	if allErrs := lbc.validateIngressResource(bar); len(allErrs) != 0 {
+		t.Errorf("expected bar to be admitted after foo was removed, got %v", errorListToStrings(allErrs))
+	}
+}
@@ -0,0 +1,386 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	mergeableIngressTypeAnnotation       = "nginx.org/mergeable-ingress-type"
+	lbMethodAnnotation                   = "nginx.org/lb-method"
+	healthChecksAnnotation               = "nginx.com/health-checks"
+	healthChecksMandatoryAnnotation      = "nginx.com/health-checks-mandatory"
+	healthChecksMandatoryQueueAnnotation = "nginx.com/health-checks-mandatory-queue"
+	slowStartAnnotation                  = "nginx.com/slow-start"
+	serverTokensAnnotation               = "nginx.org/server-tokens"
+	serverSnippetsAnnotation             = "nginx.org/server-snippets"
+	locationSnippetsAnnotation           = "nginx.org/location-snippets"
+	internalRouteAnnotation              = "nginx.org/internal-route"
+	pathRegexAnnotation                  = "nginx.org/path-regex"
+
+	appProtectEnableAnnotation    = "appprotect.f5.com/app-protect-enable"
+	appProtectPolicyAnnotation    = "appprotect.f5.com/app-protect-policy"
+	appProtectLogEnableAnnotation = "appprotect.f5.com/app-protect-security-log-enable"
+	appProtectLogConfAnnotation   = "appprotect.f5.com/app-protect-security-log"
+)
+
+// validLBMethods are the load balancing methods supported on NGINX OSS.
+var validLBMethods = map[string]bool{
+	"round_robin": true,
+	"least_conn":  true,
+	"ip_hash":     true,
+	"random":      true,
+	"hash":        true,
+}
+
+// validPlusOnlyLBMethods are the additional load balancing methods only supported on NGINX Plus.
+var validPlusOnlyLBMethods = map[string]bool{
+	"least_time":        true,
+	"least_time header": true,
+}
+
+// validPathTypes are the values accepted by the nginx.org/path-regex annotation, in the order
+// they should be reported in the "must be one of" validation message.
+var validPathTypes = []string{"prefix", "exact", "regex", "case_sensitive_regex"}
+
+// validateIngress validates an Ingress resource and all of its annotations, returning the
+// aggregated list of validation errors. isPlus indicates whether the controller is running
+// with NGINX Plus, appProtectEnabled indicates whether the AppProtect module is enabled, and
+// internalRoutesEnabled indicates whether the controller was started with support for NGINX
+// Service Mesh internal routes.
+func validateIngress(ing *networking.Ingress, isPlus bool, appProtectEnabled bool, internalRoutesEnabled bool) field.ErrorList {
+	allErrs := validateIngressAnnotations(ing.Annotations, isPlus, appProtectEnabled, internalRoutesEnabled, field.NewPath("annotations"))
+
+	mergeableType := ing.Annotations[mergeableIngressTypeAnnotation]
+	switch mergeableType {
+	case "master":
+		allErrs = append(allErrs, validateMasterSpec(&ing.Spec, field.NewPath("spec"))...)
+	case "minion":
+		allErrs = append(allErrs, validateMinionSpec(&ing.Spec, field.NewPath("spec"))...)
+	default:
+		allErrs = append(allErrs, validateIngressSpec(&ing.Spec, field.NewPath("spec"))...)
+	}
+
+	return allErrs
+}
+
+// validateIngressAnnotations validates the annotations of an Ingress resource, returning the
+// aggregated list of validation errors in alphabetical order of annotation name.
+func validateIngressAnnotations(annotations map[string]string, isPlus bool, appProtectEnabled bool, internalRoutesEnabled bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := annotations[name]
+
+		switch name {
+		case mergeableIngressTypeAnnotation:
+			allErrs = append(allErrs, validateMergeableIngressTypeAnnotation(value, fieldPath.Child(name))...)
+		case lbMethodAnnotation:
+			allErrs = append(allErrs, validateLBMethodAnnotation(value, isPlus, fieldPath.Child(name))...)
+		case healthChecksAnnotation:
+			allErrs = append(allErrs, validatePlusOnlyBoolAnnotation(value, isPlus, fieldPath.Child(name))...)
+		case healthChecksMandatoryAnnotation:
+			allErrs = append(allErrs, validateHealthChecksMandatoryAnnotation(annotations, isPlus, fieldPath.Child(name))...)
+		case healthChecksMandatoryQueueAnnotation:
+			allErrs = append(allErrs, validateHealthChecksMandatoryQueueAnnotation(annotations, isPlus, fieldPath.Child(name))...)
+		case slowStartAnnotation:
+			allErrs = append(allErrs, validateSlowStartAnnotation(value, isPlus, fieldPath.Child(name))...)
+		case serverTokensAnnotation:
+			allErrs = append(allErrs, validateServerTokensAnnotation(value, isPlus, fieldPath.Child(name))...)
+		case serverSnippetsAnnotation, locationSnippetsAnnotation:
+			// snippets are opaque NGINX configuration and are not validated here
+		case appProtectEnableAnnotation:
+			allErrs = append(allErrs, validateAppProtectBoolAnnotation(value, appProtectEnabled, fieldPath.Child(name))...)
+		case appProtectLogEnableAnnotation:
+			allErrs = append(allErrs, validateAppProtectBoolAnnotation(value, appProtectEnabled, fieldPath.Child(name))...)
+		case appProtectPolicyAnnotation, appProtectLogConfAnnotation:
+			allErrs = append(allErrs, validateAppProtectResourceRefAnnotation(value, appProtectEnabled, fieldPath.Child(name))...)
+		case internalRouteAnnotation:
+			allErrs = append(allErrs, validateInternalRouteAnnotation(value, internalRoutesEnabled, fieldPath.Child(name))...)
+		case pathRegexAnnotation:
+			allErrs = append(allErrs, validatePathRegexAnnotation(value, fieldPath.Child(name))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateMergeableIngressTypeAnnotation(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+	if value != "master" && value != "minion" {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be one of: 'master' or 'minion'"))
+	}
+
+	return allErrs
+}
+
+func validatePathRegexAnnotation(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, t := range validPathTypes {
+		if value == t {
+			return allErrs
+		}
+	}
+
+	return append(allErrs, field.Invalid(fieldPath, value, fmt.Sprintf("must be one of: %s", quotedPathTypes())))
+}
+
+func quotedPathTypes() string {
+	quoted := make([]string, 0, len(validPathTypes))
+	for _, t := range validPathTypes {
+		quoted = append(quoted, fmt.Sprintf("'%s'", t))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func validateLBMethodAnnotation(value string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if validLBMethods[value] {
+		return allErrs
+	}
+	if isPlus && validPlusOnlyLBMethods[value] {
+		return allErrs
+	}
+
+	return append(allErrs, field.Invalid(fieldPath, value, fmt.Sprintf("Invalid load balancing method: %q", value)))
+}
+
+func validatePlusOnlyBoolAnnotation(value string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !isPlus {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires NGINX Plus"))
+	}
+
+	if _, err := strconv.ParseBool(value); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a valid boolean"))
+	}
+
+	return allErrs
+}
+
+func validateHealthChecksMandatoryAnnotation(annotations map[string]string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	value := annotations[healthChecksMandatoryAnnotation]
+
+	if !isPlus {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires NGINX Plus"))
+	}
+
+	if _, err := strconv.ParseBool(value); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a valid boolean"))
+	}
+
+	healthChecks, set := annotations[healthChecksAnnotation]
+	if !set {
+		return append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("related annotation %s: must be set", healthChecksAnnotation)))
+	}
+	if ok, _ := strconv.ParseBool(healthChecks); !ok {
+		return append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("related annotation %s: must be true", healthChecksAnnotation)))
+	}
+
+	return allErrs
+}
+
+func validateHealthChecksMandatoryQueueAnnotation(annotations map[string]string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	value := annotations[healthChecksMandatoryQueueAnnotation]
+
+	if !isPlus {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires NGINX Plus"))
+	}
+
+	queue, err := strconv.Atoi(value)
+	if err != nil || queue < 0 {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a non-negative integer"))
+	}
+
+	mandatory, set := annotations[healthChecksMandatoryAnnotation]
+	if !set {
+		return append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("related annotation %s: must be set", healthChecksMandatoryAnnotation)))
+	}
+	if ok, _ := strconv.ParseBool(mandatory); !ok {
+		return append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("related annotation %s: must be true", healthChecksMandatoryAnnotation)))
+	}
+
+	return allErrs
+}
+
+func validateSlowStartAnnotation(value string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !isPlus {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires NGINX Plus"))
+	}
+
+	if _, err := time.ParseDuration(value); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a valid time"))
+	}
+
+	return allErrs
+}
+
+func validateServerTokensAnnotation(value string, isPlus bool, fieldPath *field.Path) field.ErrorList {
+	// on NGINX Plus, server-tokens also accepts a custom token string, so only OSS validates
+	// the value as a strict boolean.
+	if isPlus {
+		return field.ErrorList{}
+	}
+
+	return validateBoolAnnotation(value, fieldPath)
+}
+
+// validateBoolAnnotation validates that value is a valid boolean string.
+func validateBoolAnnotation(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, err := strconv.ParseBool(value); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a valid boolean"))
+	}
+
+	return allErrs
+}
+
+// validateInternalRouteAnnotation validates the nginx.org/internal-route annotation, which is
+// only meaningful when the controller is running with NGINX Service Mesh internal routes
+// support enabled.
+func validateInternalRouteAnnotation(value string, internalRoutesEnabled bool, fieldPath *field.Path) field.ErrorList {
+	if !internalRoutesEnabled {
+		return field.ErrorList{field.Forbidden(fieldPath, "annotation requires internal routes to be enabled")}
+	}
+
+	return validateBoolAnnotation(value, fieldPath)
+}
+
+// validateAppProtectBoolAnnotation validates the AppProtect boolean annotations
+// (app-protect-enable and app-protect-security-log-enable). The AppProtect module must be
+// enabled on the controller for these annotations to be accepted at all.
+func validateAppProtectBoolAnnotation(value string, appProtectEnabled bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !appProtectEnabled {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires AppProtect module"))
+	}
+
+	if _, err := strconv.ParseBool(value); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a valid boolean"))
+	}
+
+	return allErrs
+}
+
+// validateAppProtectResourceRefAnnotation validates the AppProtect policy and security log
+// reference annotations, which must name a resource as "namespace/name".
+func validateAppProtectResourceRefAnnotation(value string, appProtectEnabled bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !appProtectEnabled {
+		return append(allErrs, field.Forbidden(fieldPath, "annotation requires AppProtect module"))
+	}
+
+	if value == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+
+	if !isValidResourceReference(value) {
+		return append(allErrs, field.Invalid(fieldPath, value, "must be a reference to a resource in the format 'namespace/name'"))
+	}
+
+	return allErrs
+}
+
+func isValidResourceReference(value string) bool {
+	namespace, name, found := strings.Cut(value, "/")
+	if !found {
+		return false
+	}
+	return namespace != "" && name != ""
+}
+
+func validateIngressSpec(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(spec.Rules) == 0 {
+		return append(allErrs, field.Required(fieldPath.Child("rules"), ""))
+	}
+
+	hosts := make(map[string]bool)
+	for i, r := range spec.Rules {
+		idxPath := fieldPath.Child("rules").Index(i)
+		if r.Host == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("host"), ""))
+			continue
+		}
+		if hosts[r.Host] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("host"), r.Host))
+			continue
+		}
+		hosts[r.Host] = true
+	}
+
+	return allErrs
+}
+
+func validateMasterSpec(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rulesPath := fieldPath.Child("rules")
+
+	if len(spec.Rules) != 1 {
+		return append(allErrs, field.TooMany(rulesPath, len(spec.Rules), 1))
+	}
+
+	rule := spec.Rules[0]
+	if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+		allErrs = append(allErrs, field.TooMany(rulesPath.Index(0).Child("http", "paths"), len(rule.HTTP.Paths), 0))
+	}
+
+	return allErrs
+}
+
+func validateMinionSpec(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rulesPath := fieldPath.Child("rules")
+
+	if len(spec.TLS) > 0 {
+		allErrs = append(allErrs, field.TooMany(fieldPath.Child("tls"), len(spec.TLS), 0))
+	}
+
+	if len(spec.Rules) != 1 {
+		return append(allErrs, field.TooMany(rulesPath, len(spec.Rules), 1))
+	}
+
+	rule := spec.Rules[0]
+	if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath.Index(0).Child("http", "paths"), "must include at least one path"))
+	}
+
+	return allErrs
+}
+
+func errorListToStrings(list field.ErrorList) []string {
+	var result []string
+
+	for _, e := range list {
+		result = append(result, e.Error())
+	}
+
+	return result
+}
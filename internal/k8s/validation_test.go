@@ -13,10 +13,12 @@ import (
 
 func TestValidateIngress(t *testing.T) {
 	tests := []struct {
-		ing            *networking.Ingress
-		isPlus         bool
-		expectedErrors []string
-		msg            string
+		ing                   *networking.Ingress
+		isPlus                bool
+		appProtectEnabled     bool
+		internalRoutesEnabled bool
+		expectedErrors        []string
+		msg                   string
 	}{
 		{
 			ing: &networking.Ingress{
@@ -106,10 +108,53 @@ func TestValidateIngress(t *testing.T) {
 			},
 			msg: "invalid minion",
 		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Annotations: map[string]string{
+						"appprotect.f5.com/app-protect-enable": "true",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							Host: "example.com",
+						},
+					},
+				},
+			},
+			isPlus:            false,
+			appProtectEnabled: false,
+			expectedErrors: []string{
+				"annotations.appprotect.f5.com/app-protect-enable: Forbidden: annotation requires AppProtect module",
+			},
+			msg: "invalid ingress, AppProtect module not enabled",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Annotations: map[string]string{
+						"appprotect.f5.com/app-protect-enable": "true",
+						"appprotect.f5.com/app-protect-policy": "default/dataguard-alarm",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							Host: "example.com",
+						},
+					},
+				},
+			},
+			isPlus:            false,
+			appProtectEnabled: true,
+			expectedErrors:    nil,
+			msg:               "valid ingress with AppProtect annotations",
+		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateIngress(test.ing, test.isPlus)
+		allErrs := validateIngress(test.ing, test.isPlus, test.appProtectEnabled, test.internalRoutesEnabled)
 		assertion := assertErrors("validateIngress()", test.msg, allErrs, test.expectedErrors)
 		if assertion != "" {
 			t.Error(assertion)
@@ -119,6 +164,8 @@ func TestValidateIngress(t *testing.T) {
 
 func TestValidateNginxIngressAnnotations(t *testing.T) {
 	isPlus := false
+	appProtectEnabled := false
+	internalRoutesEnabled := false
 	tests := []struct {
 		annotations    map[string]string
 		expectedErrors []string
@@ -175,6 +222,23 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			msg: "invalid mergeable type annotation 2",
 		},
 
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "prefix",
+			},
+			expectedErrors: nil,
+			msg:            "valid nginx.org/path-regex annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "invalid_type",
+			},
+			expectedErrors: []string{
+				`annotations.nginx.org/path-regex: Invalid value: "invalid_type": must be one of: 'prefix', 'exact', 'regex', 'case_sensitive_regex'`,
+			},
+			msg: "invalid nginx.org/path-regex annotation",
+		},
+
 		{
 			annotations: map[string]string{
 				"nginx.org/lb-method": "random",
@@ -280,11 +344,31 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			expectedErrors: nil,
 			msg:            "valid nginx.org/location-snippets annotation, multi-line",
 		},
+
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-enable": "true",
+			},
+			expectedErrors: []string{
+				"annotations.appprotect.f5.com/app-protect-enable: Forbidden: annotation requires AppProtect module",
+			},
+			msg: "invalid appprotect.f5.com/app-protect-enable annotation, AppProtect module not enabled",
+		},
+
+		{
+			annotations: map[string]string{
+				"nginx.org/internal-route": "true",
+			},
+			expectedErrors: []string{
+				"annotations.nginx.org/internal-route: Forbidden: annotation requires internal routes to be enabled",
+			},
+			msg: "invalid nginx.org/internal-route annotation, internal routes not enabled",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.msg, func(t *testing.T) {
-			allErrs := validateIngressAnnotations(test.annotations, isPlus, field.NewPath("annotations"))
+			allErrs := validateIngressAnnotations(test.annotations, isPlus, appProtectEnabled, internalRoutesEnabled, field.NewPath("annotations"))
 			assertion := assertErrors("validateIngressAnnotations()", test.msg, allErrs, test.expectedErrors)
 			if assertion != "" {
 				t.Error(assertion)
@@ -295,6 +379,8 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 
 func TestValidateNginxPlusIngressAnnotations(t *testing.T) {
 	isPlus := true
+	appProtectEnabled := true
+	internalRoutesEnabled := true
 	tests := []struct {
 		annotations    map[string]string
 		expectedErrors []string
@@ -351,6 +437,23 @@ func TestValidateNginxPlusIngressAnnotations(t *testing.T) {
 			msg: "invalid mergeable type annotation 2",
 		},
 
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "prefix",
+			},
+			expectedErrors: nil,
+			msg:            "valid nginx.org/path-regex annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "invalid_type",
+			},
+			expectedErrors: []string{
+				`annotations.nginx.org/path-regex: Invalid value: "invalid_type": must be one of: 'prefix', 'exact', 'regex', 'case_sensitive_regex'`,
+			},
+			msg: "invalid nginx.org/path-regex annotation",
+		},
+
 		{
 			annotations: map[string]string{
 				"nginx.org/lb-method": "least_time header",
@@ -518,11 +621,69 @@ func TestValidateNginxPlusIngressAnnotations(t *testing.T) {
 			expectedErrors: nil,
 			msg:            "valid nginx.org/location-snippets annotation, multi-line",
 		},
+
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-enable": "true",
+			},
+			expectedErrors: nil,
+			msg:            "valid appprotect.f5.com/app-protect-enable annotation",
+		},
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-enable": "not_a_boolean",
+			},
+			expectedErrors: []string{
+				`annotations.appprotect.f5.com/app-protect-enable: Invalid value: "not_a_boolean": must be a valid boolean`,
+			},
+			msg: "invalid appprotect.f5.com/app-protect-enable annotation, must be a boolean",
+		},
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-security-log-enable": "true",
+				"appprotect.f5.com/app-protect-security-log":        "default/logconf",
+			},
+			expectedErrors: nil,
+			msg:            "valid appprotect.f5.com/app-protect-security-log-enable and app-protect-security-log annotations",
+		},
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-policy": "default/dataguard-alarm",
+			},
+			expectedErrors: nil,
+			msg:            "valid appprotect.f5.com/app-protect-policy annotation",
+		},
+		{
+			annotations: map[string]string{
+				"appprotect.f5.com/app-protect-policy": "dataguard-alarm",
+			},
+			expectedErrors: []string{
+				`annotations.appprotect.f5.com/app-protect-policy: Invalid value: "dataguard-alarm": must be a reference to a resource in the format 'namespace/name'`,
+			},
+			msg: "invalid appprotect.f5.com/app-protect-policy annotation, not a namespace/name reference",
+		},
+
+		{
+			annotations: map[string]string{
+				"nginx.org/internal-route": "true",
+			},
+			expectedErrors: nil,
+			msg:            "valid nginx.org/internal-route annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/internal-route": "not_a_boolean",
+			},
+			expectedErrors: []string{
+				`annotations.nginx.org/internal-route: Invalid value: "not_a_boolean": must be a valid boolean`,
+			},
+			msg: "invalid nginx.org/internal-route annotation, must be a boolean",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.msg, func(t *testing.T) {
-			allErrs := validateIngressAnnotations(test.annotations, isPlus, field.NewPath("annotations"))
+			allErrs := validateIngressAnnotations(test.annotations, isPlus, appProtectEnabled, internalRoutesEnabled, field.NewPath("annotations"))
 			assertion := assertErrors("validateIngressAnnotations()", test.msg, allErrs, test.expectedErrors)
 			if assertion != "" {
 				t.Error(assertion)
@@ -777,13 +938,3 @@ func assertErrors(funcName string, msg string, allErrs field.ErrorList, expected
 
 	return ""
 }
-
-func errorListToStrings(list field.ErrorList) []string {
-	var result []string
-
-	for _, e := range list {
-		result = append(result, e.Error())
-	}
-
-	return result
-}
@@ -0,0 +1,114 @@
+// Package webhook implements a ValidatingAdmissionWebhook HTTP handler that runs the same
+// Ingress validation the controller applies during reconciliation, but at kubectl apply time.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	admission "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	_ = admission.AddToScheme(scheme)
+	_ = networking.AddToScheme(scheme)
+}
+
+// Config holds the controller flags the webhook must apply the same validation rules as the
+// running controller, and the conflict store that catches cross-Ingress host/path collisions.
+type Config struct {
+	IsPlus                bool
+	AppProtectEnabled     bool
+	InternalRoutesEnabled bool
+
+	// Conflicts tracks host/path claims across the Ingresses the controller watches. When
+	// set, the handler rejects an Ingress whose rules collide with another Ingress's claims
+	// at admission time instead of only catching the conflict later in the controller's
+	// informer loop, by which point the conflicting resource is already persisted. It is
+	// nil-safe: a zero-value Config skips the conflict check.
+	Conflicts *k8s.IngressConflictValidator
+}
+
+// Handler returns an http.Handler that decodes AdmissionReview requests, validates the
+// enclosed Ingress using the same rules the controller applies during its sync loop, and
+// responds with an AdmissionReview carrying the aggregated validation errors.
+func Handler(cfg Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			http.Error(w, "empty request body", http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		review := &admission.AdmissionReview{}
+		if _, _, err := codecs.UniversalDeserializer().Decode(body, nil, review); err != nil {
+			http.Error(w, fmt.Sprintf("could not decode admission review: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		response := reviewIngress(review, cfg)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func reviewIngress(review *admission.AdmissionReview, cfg Config) *admission.AdmissionReview {
+	req := review.Request
+
+	response := &admission.AdmissionReview{
+		TypeMeta: review.TypeMeta,
+		Response: &admission.AdmissionResponse{
+			UID:     req.UID,
+			Allowed: true,
+		},
+	}
+
+	ing := &networking.Ingress{}
+	if _, _, err := codecs.UniversalDeserializer().Decode(req.Object.Raw, nil, ing); err != nil {
+		response.Response.Allowed = false
+		response.Response.Result = &meta_v1.Status{Message: fmt.Sprintf("could not decode Ingress: %s", err)}
+		return response
+	}
+
+	allErrs := k8s.ValidateIngress(ing, cfg.IsPlus, cfg.AppProtectEnabled, cfg.InternalRoutesEnabled)
+
+	if cfg.Conflicts != nil {
+		if len(allErrs) > 0 {
+			// Already invalid on its own terms; report conflicts too but never claim its paths.
+			allErrs = append(allErrs, cfg.Conflicts.Validate(ing, field.NewPath("spec"))...)
+		} else {
+			allErrs = cfg.Conflicts.ValidateAndUpsert(ing, field.NewPath("spec"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		response.Response.Allowed = false
+		message := strings.Join(k8s.ErrorListToStrings(allErrs), ", ")
+		response.Response.Result = &meta_v1.Status{Message: message}
+	}
+
+	return response
+}
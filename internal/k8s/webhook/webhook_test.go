@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	admission "k8s.io/api/admission/v1"
+)
+
+const validIngressReview = `{
+	"kind": "AdmissionReview",
+	"apiVersion": "admission.k8s.io/v1",
+	"request": {
+		"uid": "test-uid",
+		"object": {
+			"apiVersion": "networking.k8s.io/v1beta1",
+			"kind": "Ingress",
+			"metadata": {"name": "foo", "namespace": "default"},
+			"spec": {"rules": [{"host": "example.com"}]}
+		}
+	}
+}`
+
+const invalidIngressReview = `{
+	"kind": "AdmissionReview",
+	"apiVersion": "admission.k8s.io/v1",
+	"request": {
+		"uid": "test-uid",
+		"object": {
+			"apiVersion": "networking.k8s.io/v1beta1",
+			"kind": "Ingress",
+			"metadata": {
+				"name": "foo",
+				"namespace": "default",
+				"annotations": {"nginx.org/mergeable-ingress-type": "invalid"}
+			},
+			"spec": {"rules": [{"host": ""}]}
+		}
+	}
+}`
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		msg             string
+		body            string
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			msg:             "valid ingress is allowed",
+			body:            validIngressReview,
+			expectedAllowed: true,
+		},
+		{
+			msg:             "invalid ingress is rejected with the aggregated validation errors",
+			body:            invalidIngressReview,
+			expectedAllowed: false,
+			expectedMessage: `annotations.nginx.org/mergeable-ingress-type: Invalid value: "invalid": must be one of: 'master' or 'minion', spec.rules[0].host: Required value`,
+		},
+	}
+
+	handler := Handler(Config{})
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(test.body))
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			var review admission.AdmissionReview
+			if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+				t.Fatalf("could not decode response: %v", err)
+			}
+
+			if review.Response.Allowed != test.expectedAllowed {
+				t.Errorf("got Allowed=%v, expected %v", review.Response.Allowed, test.expectedAllowed)
+			}
+			if test.expectedMessage != "" {
+				if review.Response.Result == nil {
+					t.Fatalf("got no Result, expected message %q", test.expectedMessage)
+				}
+				if review.Response.Result.Message != test.expectedMessage {
+					t.Errorf("got message %q, expected %q", review.Response.Result.Message, test.expectedMessage)
+				}
+			}
+		})
+	}
+}
+
+const fooPathIngressReview = `{
+	"kind": "AdmissionReview",
+	"apiVersion": "admission.k8s.io/v1",
+	"request": {
+		"uid": "test-uid",
+		"object": {
+			"apiVersion": "networking.k8s.io/v1beta1",
+			"kind": "Ingress",
+			"metadata": {"name": "foo", "namespace": "default"},
+			"spec": {"rules": [{"host": "example.com", "http": {"paths": [{"path": "/"}]}}]}
+		}
+	}
+}`
+
+const barPathIngressReview = `{
+	"kind": "AdmissionReview",
+	"apiVersion": "admission.k8s.io/v1",
+	"request": {
+		"uid": "test-uid",
+		"object": {
+			"apiVersion": "networking.k8s.io/v1beta1",
+			"kind": "Ingress",
+			"metadata": {"name": "bar", "namespace": "default"},
+			"spec": {"rules": [{"host": "example.com", "http": {"paths": [{"path": "/"}]}}]}
+		}
+	}
+}`
+
+func TestHandlerConflicts(t *testing.T) {
+	handler := Handler(Config{Conflicts: k8s.NewIngressConflictValidator()})
+
+	post := func(body string) *admission.AdmissionReview {
+		req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var review admission.AdmissionReview
+		if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+			t.Fatalf("could not decode response: %v", err)
+		}
+		return &review
+	}
+
+	if review := post(fooPathIngressReview); !review.Response.Allowed {
+		t.Fatalf("expected foo to be allowed, got Result %v", review.Response.Result)
+	}
+
+	review := post(barPathIngressReview)
+	if review.Response.Allowed {
+		t.Fatal("expected bar to be rejected as a conflict with foo, but it was allowed")
+	}
+	expectedMessage := `spec.rules[0].http.paths[0].path: Duplicate value: "/": already defined by "default/foo"`
+	if review.Response.Result.Message != expectedMessage {
+		t.Errorf("got message %q, expected %q", review.Response.Result.Message, expectedMessage)
+	}
+}
@@ -0,0 +1,20 @@
+package k8s
+
+import (
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateIngress runs the same Ingress validation used during reconciliation and is exported
+// for the admission webhook (internal/k8s/webhook), which rejects invalid Ingresses at
+// kubectl apply time instead of leaving the controller to silently skip them mid-reconcile.
+func ValidateIngress(ing *networking.Ingress, isPlus bool, appProtectEnabled bool, internalRoutesEnabled bool) field.ErrorList {
+	return validateIngress(ing, isPlus, appProtectEnabled, internalRoutesEnabled)
+}
+
+// ErrorListToStrings formats a field.ErrorList into the same error strings the validation
+// test suite asserts against, so the webhook can return the exact strings admins already see
+// in controller logs.
+func ErrorListToStrings(list field.ErrorList) []string {
+	return errorListToStrings(list)
+}